@@ -1,76 +1,188 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
 	"math"
 	"math/rand"
+	"sort"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/quartermeat/cell_auto_port/component"
+	"github.com/quartermeat/cell_auto_port/input"
+	"github.com/quartermeat/cell_auto_port/save"
+	"github.com/quartermeat/cell_auto_port/system"
 )
 
+// defaultSaveFile is where [F5]/[F9] save and load the board as
+// human-readable JSON; defaultSaveFileGob is where [F6]/[F10] save and
+// load the same Snapshot in gob's more compact binary form.
 const (
-	initialWidth      = 800
-	initialHeight     = 800
-	cellSize          = 10
-	zoomStep          = 1.05
-	minZoom, maxZoom  = 0.25, 5.0
-	fullscreenKeyCode = ebiten.KeyF11
-	minSpeed          = 0.1
-	maxSpeed          = 10.0
-	speedStep = 0.1
+	defaultSaveFile    = "savegame.json"
+	defaultSaveFileGob = "savegame.gob"
 )
 
+const (
+	initialWidth     = 800
+	initialHeight    = 800
+	cellSize         = 10
+	zoomStep         = 1.05
+	minZoom, maxZoom = 0.25, 5.0
+	minSpeed         = 0.1
+	maxSpeed         = 10.0
+	speedStep        = 0.1
+	maxBrushRadius   = 20
+	maxPopHistory    = 150
+)
+
+// popSample is one tick's worth of per-species population, kept around
+// to draw the mini population graph.
+type popSample struct {
+	life, zombie, food int
+}
+
 var (
 	worldWidth  = 4000
 	worldHeight = 4000
 )
 
-type LifeType string
-
-const (
-	Life   LifeType = "life"
-	Zombie LifeType = "zombie"
-)
+// Game is the ECS scheduler: it owns the World and the systems that
+// tick over it, plus the camera/UI state that has no business being a
+// component (there's only ever one camera).
+type Game struct {
+	world *component.World
 
-type Cell struct {
-	X, Y int
-	Type LifeType
-}
+	movementSystem *system.MovementSystem
+	ruleSystem     system.Simulator
+	renderSystem   *system.RenderSystem
+	inputSystem    *system.InputSystem
+	dispatcher     *input.Dispatcher
 
-type Game struct {
-	cells        []Cell
 	zoom         float64
 	cameraX      float64
 	cameraY      float64
 	fullscreen   bool
-	prevF11Down  bool
 	gameSpeed    float64
 	speedCounter float64
-	occupied     map[[2]int]bool
-	placeType    LifeType
+	placeType    component.LifeType
 	showUI       bool
+	rulePreset   int
+	gameOver     bool
+	gameOverMsg  string
+
+	mouseX, mouseY float64
+
+	brushRadius  int
+	brushShape   system.BrushShape
+	lastPlaceX   int
+	lastPlaceY   int
+	hasLastPlace bool
+
+	panelWidth  float64
+	panelHeight float64
+
+	popHistory []popSample
+
+	seed      int64
+	rng       *rand.Rand // seeded at NewGame time so any randomized rule replays deterministically
+	tickCount int
+	events    []save.InputEvent
+	lastSpeed float64
+
+	redrawCh chan struct{}
+}
+
+// NewGame returns a fresh Game seeded with seed, using the default key
+// bindings. Every call with the same seed produces an identical initial
+// RNG stream, so a recorded event log can be replayed frame-accurately
+// from here.
+func NewGame(seed int64) *Game {
+	return NewGameWithBindings(seed, input.DefaultBindings())
 }
 
-func NewGame() *Game {
-	return &Game{
-		cells:     []Cell{},
-		zoom:      1.0,
-		cameraX:   float64(worldWidth) / 2,
-		cameraY:   float64(worldHeight) / 2,
-		gameSpeed: 1.0,
-		occupied:  make(map[[2]int]bool),
-		placeType: Life,
-		showUI:    true,
+// NewGameWithBindings is NewGame with caller-supplied key bindings, for
+// --keybindings config files and anything that wants to rebind controls.
+// It starts this Game's redrawLoop goroutine; resetting an existing Game
+// in place should go through resetWithSeed instead, which carries the
+// running goroutine over rather than orphaning it.
+func NewGameWithBindings(seed int64, bindings input.KeyBindings) *Game {
+	g := newGame(seed, bindings)
+	g.redrawCh = make(chan struct{}, 1)
+	go g.redrawLoop()
+	return g
+}
+
+// newGame builds every piece of fresh Game state except redrawCh, which
+// callers wire up themselves: NewGameWithBindings gives it a new channel
+// and goroutine, while resetWithSeed carries over the caller's existing
+// ones so resetting a running Game never leaks a redrawLoop blocked on
+// an orphaned channel.
+func newGame(seed int64, bindings input.KeyBindings) *Game {
+	rng := rand.New(rand.NewSource(seed))
+	g := &Game{
+		world:          component.NewWorld(),
+		movementSystem: system.NewMovementSystem(),
+		ruleSystem:     system.NewSimulatorForPreset(0, rng),
+		renderSystem:   system.NewRenderSystem(),
+		inputSystem:    system.NewInputSystem(worldWidth/cellSize, worldHeight/cellSize),
+		dispatcher:     input.NewDispatcher(bindings),
+		zoom:           1.0,
+		cameraX:        float64(worldWidth) / 2,
+		cameraY:        float64(worldHeight) / 2,
+		gameSpeed:      1.0,
+		placeType:      component.Life,
+		showUI:         true,
+		brushRadius:    0,
+		brushShape:     system.BrushSquare,
+		seed:           seed,
+		rng:            rng,
+		lastSpeed:      1.0,
+	}
+	g.dispatcher.Subscribe(g.handleInput)
+	return g
+}
+
+// resetWithSeed reinitializes g in place to a fresh game using seed and
+// bindings, carrying over g's existing redrawCh instead of handing it to
+// a new goroutine — every [R] reset and game-over restart used to do
+// *g = *NewGameWithBindings(...), which replaced redrawCh out from under
+// the old redrawLoop goroutine and left it blocked on the orphaned
+// channel forever.
+func (g *Game) resetWithSeed(seed int64, bindings input.KeyBindings) {
+	redrawCh := g.redrawCh
+	fresh := newGame(seed, bindings)
+	fresh.redrawCh = redrawCh
+	*g = *fresh
+	g.markDirty()
+}
+
+// redrawLoop asks Ebiten to schedule one more frame every time
+// markDirty signals the channel, instead of the game looping at a
+// fixed rate regardless of whether anything changed. A paused,
+// untouched simulation this way costs no GPU time at all.
+func (g *Game) redrawLoop() {
+	for range g.redrawCh {
+		ebiten.ScheduleFrame()
+	}
+}
+
+// markDirty wakes redrawLoop to schedule one more frame.
+func (g *Game) markDirty() {
+	select {
+	case g.redrawCh <- struct{}{}:
+	default:
 	}
 }
 
 func (g *Game) screenToWorld(screenX, screenY float64, screenW, screenH float64) (float64, float64) {
 	cx, cy := screenW/2, screenH/2
-	wx := (screenX - cx)/g.zoom + g.cameraX
-	wy := (screenY - cy)/g.zoom + g.cameraY
+	wx := (screenX-cx)/g.zoom + g.cameraX
+	wy := (screenY-cy)/g.zoom + g.cameraY
 	return wx, wy
 }
 
@@ -95,177 +207,490 @@ func (g *Game) clampCamera(screenW, screenH float64) {
 }
 
 func (g *Game) Update() error {
-	screenW, screenH := ebiten.WindowSize()
-	mouseX, mouseY := ebiten.CursorPosition()
-	mx, my := float64(mouseX), float64(mouseY)
-	beforeX, beforeY := g.screenToWorld(mx, my, float64(screenW), float64(screenH))
-
-	f11Down := ebiten.IsKeyPressed(fullscreenKeyCode)
-	if f11Down && !g.prevF11Down {
-		g.fullscreen = !g.fullscreen
-		ebiten.SetFullscreen(g.fullscreen)
+	if g.gameOver {
+		if ebiten.IsKeyPressed(ebiten.KeyEnter) {
+			g.resetWithSeed(g.seed, g.dispatcher.Bindings)
+		}
+		return nil
 	}
-	g.prevF11Down = f11Down
 
-	if ebiten.IsKeyPressed(ebiten.KeyTab) {
-		g.showUI = true
-	} else {
-		g.showUI = false
+	startCameraX, startCameraY, startZoom := g.cameraX, g.cameraY, g.zoom
+
+	screenW, screenH := ebiten.WindowSize()
+	g.showUI = false
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		g.hasLastPlace = false
 	}
+	g.dispatcher.Poll(screenW, screenH)
 
-	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
-		g.gameSpeed = math.Min(g.gameSpeed+speedStep, maxSpeed)
+	if g.gameSpeed != g.lastSpeed {
+		g.recordEvent(save.InputEvent{Tick: g.tickCount, Kind: "speed", GameSpeed: g.gameSpeed})
+		g.lastSpeed = g.gameSpeed
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
-		g.gameSpeed = math.Max(g.gameSpeed-speedStep, minSpeed)
+
+	g.clampCamera(float64(screenW), float64(screenH))
+
+	g.speedCounter += g.gameSpeed
+	if g.speedCounter >= 1.0 {
+		updates := int(math.Floor(g.speedCounter))
+		for i := 0; i < updates; i++ {
+			g.tick()
+		}
+		g.speedCounter -= float64(updates)
+		g.markDirty()
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyQ) {
-		g.zoom *= zoomStep
+	if g.cameraX != startCameraX || g.cameraY != startCameraY || g.zoom != startZoom {
+		g.markDirty()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyE) {
-		g.zoom /= zoomStep
+
+	return nil
+}
+
+// handleInput is the Game's single input.Handler: it type-switches on
+// whatever the dispatcher emitted and routes it to the matching field
+// or system. Registered once, in newGame.
+func (g *Game) handleInput(e input.Event) {
+	switch ev := e.(type) {
+	case input.MouseMoveEvent:
+		g.mouseX, g.mouseY = ev.X, ev.Y
+	case input.KeyPressEvent:
+		g.handleKeyPress(ev)
+	case input.MouseDownEvent:
+		g.handleMouseDown(ev)
+	case input.WindowResizeEvent:
+		g.handleWindowResize(ev)
 	}
-	_, scrollY := ebiten.Wheel()
-	if scrollY > 0 {
-		g.zoom *= zoomStep
-	} else if scrollY < 0 {
-		g.zoom /= zoomStep
+}
+
+func (g *Game) moveSpeed() float64 {
+	return 10.0 / g.zoom
+}
+
+// brushShapeName is the HUD label for g.brushShape.
+func (g *Game) brushShapeName() string {
+	if g.brushShape == system.BrushCircle {
+		return "(circle)"
 	}
-	g.zoom = math.Max(minZoom, math.Min(g.zoom, maxZoom))
+	return "(square)"
+}
 
+// zoomAround rescales the camera by factor while keeping the world
+// point under (mx, my) fixed on screen, so scrolling or pressing the
+// zoom keys zooms toward the cursor instead of the screen center.
+func (g *Game) zoomAround(mx, my, factor float64) {
+	screenW, screenH := ebiten.WindowSize()
+	beforeX, beforeY := g.screenToWorld(mx, my, float64(screenW), float64(screenH))
+	g.zoom = math.Max(minZoom, math.Min(g.zoom*factor, maxZoom))
 	afterX, afterY := g.screenToWorld(mx, my, float64(screenW), float64(screenH))
 	g.cameraX += beforeX - afterX
 	g.cameraY += beforeY - afterY
+}
 
-	moveSpeed := 10.0 / g.zoom
-	if ebiten.IsKeyPressed(ebiten.KeyW) {
-		g.cameraY -= moveSpeed
+func (g *Game) handleKeyPress(ev input.KeyPressEvent) {
+	switch ev.Action {
+	case input.ActionToggleUI:
+		g.showUI = true
+	case input.ActionZoomIn:
+		g.zoomAround(g.mouseX, g.mouseY, zoomStep)
+	case input.ActionZoomOut:
+		g.zoomAround(g.mouseX, g.mouseY, 1/zoomStep)
+	case input.ActionPanUp:
+		g.cameraY -= g.moveSpeed()
+	case input.ActionPanDown:
+		g.cameraY += g.moveSpeed()
+	case input.ActionPanLeft:
+		g.cameraX -= g.moveSpeed()
+	case input.ActionPanRight:
+		g.cameraX += g.moveSpeed()
+	case input.ActionSpeedUp:
+		g.gameSpeed = math.Min(g.gameSpeed+speedStep, maxSpeed)
+	case input.ActionSpeedDown:
+		g.gameSpeed = math.Max(g.gameSpeed-speedStep, minSpeed)
+	case input.ActionPlaceLife:
+		g.placeType = component.Life
+	case input.ActionPlaceZombie:
+		g.placeType = component.Zombie
+	case input.ActionToggleFullscreen:
+		g.fullscreen = !g.fullscreen
+		ebiten.SetFullscreen(g.fullscreen)
+	case input.ActionCyclePreset:
+		g.rulePreset = (g.rulePreset + 1) % len(system.PresetNames)
+		g.ruleSystem = system.NewSimulatorForPreset(g.rulePreset, g.rng)
+		g.popHistory = nil
+		g.recordEvent(save.InputEvent{Tick: g.tickCount, Kind: "rulePreset", Preset: g.rulePreset})
+		g.markDirty()
+	case input.ActionSave:
+		if err := save.SaveJSON(defaultSaveFile, g.Snapshot()); err != nil {
+			log.Printf("save failed: %v", err)
+		}
+	case input.ActionLoad:
+		if snap, err := save.LoadJSON(defaultSaveFile); err != nil {
+			log.Printf("load failed: %v", err)
+		} else {
+			g.LoadSnapshot(snap)
+		}
+	case input.ActionSaveCompact:
+		if err := save.SaveGob(defaultSaveFileGob, g.Snapshot()); err != nil {
+			log.Printf("compact save failed: %v", err)
+		}
+	case input.ActionLoadCompact:
+		if snap, err := save.LoadGob(defaultSaveFileGob); err != nil {
+			log.Printf("compact load failed: %v", err)
+		} else {
+			g.LoadSnapshot(snap)
+		}
+	case input.ActionReset:
+		g.resetWithSeed(g.seed, g.dispatcher.Bindings)
+	case input.ActionBrushGrow:
+		g.brushRadius = int(math.Min(float64(g.brushRadius+1), maxBrushRadius))
+		g.markDirty()
+	case input.ActionBrushShrink:
+		g.brushRadius = int(math.Max(float64(g.brushRadius-1), 0))
+		g.markDirty()
+	case input.ActionToggleBrushShape:
+		if g.brushShape == system.BrushSquare {
+			g.brushShape = system.BrushCircle
+		} else {
+			g.brushShape = system.BrushSquare
+		}
+		g.markDirty()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) {
-		g.cameraY += moveSpeed
+}
+
+// handleMouseDown paints cells under the cursor. Holding shift connects
+// this placement to the last one with a line, so dragging the mouse
+// fast doesn't leave gaps between placed cells.
+func (g *Game) handleMouseDown(ev input.MouseDownEvent) {
+	screenW, screenH := ebiten.WindowSize()
+	wx, wy := g.screenToWorld(ev.X, ev.Y, float64(screenW), float64(screenH))
+	cx := int(wx) / cellSize
+	cy := int(wy) / cellSize
+
+	if cx < 0 || cy < 0 || cx*cellSize >= worldWidth || cy*cellSize >= worldHeight {
+		g.hasLastPlace = false
+		return
+	}
+
+	var placed int
+	isLine := ev.Shift && g.hasLastPlace
+	if isLine {
+		placed = g.inputSystem.PlaceLine(g.world, g.lastPlaceX, g.lastPlaceY, cx, cy, g.brushRadius, g.brushShape, g.placeType)
+	} else {
+		placed = g.inputSystem.PlaceBrush(g.world, cx, cy, g.brushRadius, g.brushShape, g.placeType)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyA) {
-		g.cameraX -= moveSpeed
+
+	if placed > 0 {
+		recorded := save.InputEvent{
+			Tick: g.tickCount, Kind: "place", X: cx, Y: cy, LifeType: string(g.placeType),
+			BrushRadius: g.brushRadius, BrushShape: int(g.brushShape),
+		}
+		if isLine {
+			recorded.Line = true
+			recorded.X0, recorded.Y0 = g.lastPlaceX, g.lastPlaceY
+		}
+		g.recordEvent(recorded)
+		g.markDirty()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) {
-		g.cameraX += moveSpeed
+
+	g.lastPlaceX, g.lastPlaceY = cx, cy
+	g.hasLastPlace = true
+}
+
+// handleWindowResize rescales the UI panel to the new screen size
+// instead of drawing it at a fixed pixel size that only fit the
+// original window.
+func (g *Game) handleWindowResize(ev input.WindowResizeEvent) {
+	g.panelWidth = math.Max(250, float64(ev.Width)*0.3)
+	g.panelHeight = math.Max(260, float64(ev.Height)*0.3)
+	g.markDirty()
+}
+
+// tick runs every simulation system once, in the order movement then
+// rules, and checks for population collapse afterward.
+func (g *Game) tick() {
+	lifeBefore := g.world.CountSpecies(component.Life)
+	zombieBefore := g.world.CountSpecies(component.Zombie)
+
+	g.movementSystem.Update(g.world, 1.0)
+	g.ruleSystem.Update(g.world)
+	g.tickCount++
+
+	g.popHistory = append(g.popHistory, popSample{
+		life:   g.world.CountSpecies(component.Life),
+		zombie: g.world.CountSpecies(component.Zombie),
+		food:   g.world.CountSpecies(component.Food),
+	})
+	if len(g.popHistory) > maxPopHistory {
+		g.popHistory = g.popHistory[len(g.popHistory)-maxPopHistory:]
 	}
 
-	if ebiten.IsKeyPressed(ebiten.Key1) {
-		g.placeType = Life
+	if lifeBefore > 0 && g.world.CountSpecies(component.Life) == 0 {
+		g.gameOver = true
+		g.gameOverMsg = fmt.Sprintf("%s has died out — population collapse", component.Life)
 	}
-	if ebiten.IsKeyPressed(ebiten.Key2) {
-		g.placeType = Zombie
+	if zombieBefore > 0 && g.world.CountSpecies(component.Zombie) == 0 {
+		g.gameOver = true
+		g.gameOverMsg = fmt.Sprintf("%s has died out — population collapse", component.Zombie)
 	}
+}
 
-	g.clampCamera(float64(screenW), float64(screenH))
+// recordEvent appends ev to the session's input log, which a Snapshot
+// carries along so Replay can reconstruct the run from its seed instead
+// of just its final board.
+func (g *Game) recordEvent(ev save.InputEvent) {
+	g.events = append(g.events, ev)
+}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		wx, wy := g.screenToWorld(mx, my, float64(screenW), float64(screenH))
-		cx := int(wx) / cellSize
-		cy := int(wy) / cellSize
-		pos := [2]int{cx, cy}
-		if !g.occupied[pos] && cx >= 0 && cy >= 0 && cx*cellSize < worldWidth && cy*cellSize < worldHeight {
-			g.occupied[pos] = true
-			g.cells = append(g.cells, Cell{X: cx, Y: cy, Type: g.placeType})
+// Snapshot captures enough of the current Game to resume it directly via
+// LoadSnapshot (seed, tick, camera, the full board) or reconstruct it
+// from scratch via Replay (the seed plus the input log recorded since).
+func (g *Game) Snapshot() save.Snapshot {
+	cells := make([]save.Cell, 0, len(g.world.Positions))
+	for e, pos := range g.world.Positions {
+		species, ok := g.world.Species[e]
+		if !ok {
+			continue
 		}
-	}
-
-	g.speedCounter += g.gameSpeed
-	if g.speedCounter >= 1.0 {
-		updates := int(math.Floor(g.speedCounter))
-		for i := 0; i < updates; i++ {
-			g.logicUpdate()
+		cell := save.Cell{X: pos.X, Y: pos.Y, Type: string(species.Type)}
+		if health, ok := g.world.Healths[e]; ok {
+			cell.Health = health.Current
 		}
-		g.speedCounter -= float64(updates)
+		if age, ok := g.world.Ages[e]; ok {
+			cell.Age = age.Ticks
+		}
+		if energy, ok := g.world.Energies[e]; ok {
+			cell.Energy = energy.Current
+		}
+		if infection, ok := g.world.Infections[e]; ok {
+			cell.InfectionTicks = infection.TicksRemaining
+		}
+		cells = append(cells, cell)
 	}
 
-	return nil
+	return save.Snapshot{
+		Seed:        g.seed,
+		Tick:        g.tickCount,
+		WorldWidth:  worldWidth,
+		WorldHeight: worldHeight,
+		CellSize:    cellSize,
+		CameraX:     g.cameraX,
+		CameraY:     g.cameraY,
+		Zoom:        g.zoom,
+		RulePreset:  g.rulePreset,
+		Cells:       cells,
+		Events:      g.events,
+	}
 }
 
-func (g *Game) logicUpdate() {
-	newPositions := map[[2]int]bool{}
-	newCells := make([]Cell, 0, len(g.cells))
+// LoadSnapshot replaces g's board, camera, and RNG state with snap's,
+// rebuilding one entity per saved Cell. The RNG is reseeded from
+// snap.Seed, so it restarts that seed's stream from the beginning; it
+// does not resume at the point in the stream the snapshot was taken at.
+func (g *Game) LoadSnapshot(snap save.Snapshot) {
+	g.world = component.NewWorld()
+	for _, c := range snap.Cells {
+		e := g.world.Spawn(component.Position{X: c.X, Y: c.Y}, component.Species{Type: component.LifeType(c.Type)})
+		if c.Health != 0 {
+			max := c.Health
+			if speciesMax, ok := system.MaxHealthFor(component.LifeType(c.Type)); ok {
+				max = speciesMax
+			}
+			g.world.Healths[e] = &component.Health{Current: c.Health, Max: max}
+		}
+		if c.Age != 0 {
+			g.world.Ages[e] = &component.Age{Ticks: c.Age}
+		}
+		if c.Energy != 0 {
+			g.world.Energies[e] = &component.Energy{Current: c.Energy}
+		}
+		if c.InfectionTicks != 0 {
+			g.world.Infections[e] = &component.Infection{TicksRemaining: c.InfectionTicks}
+		}
+	}
 
-	for _, cell := range g.cells {
-		dx := rand.Intn(3) - 1
-		dy := rand.Intn(3) - 1
-		nx := cell.X + dx
-		ny := cell.Y + dy
+	g.seed = snap.Seed
+	g.rng = rand.New(rand.NewSource(snap.Seed))
+	g.tickCount = snap.Tick
+	g.cameraX = snap.CameraX
+	g.cameraY = snap.CameraY
+	g.zoom = snap.Zoom
+	g.rulePreset = snap.RulePreset
+	g.ruleSystem = system.NewSimulatorForPreset(g.rulePreset, g.rng)
+	g.events = snap.Events
+	g.popHistory = nil
+	g.gameOver = false
+}
 
-		if nx < 0 || ny < 0 || nx*cellSize >= worldWidth || ny*cellSize >= worldHeight {
-			nx, ny = cell.X, cell.Y
+// Replay rebuilds g from snap.Seed and snap.Events rather than trusting
+// snap's stored board: it starts a fresh game at the seed, then ticks it
+// forward to snap.Tick, re-applying each recorded event at the tick it
+// originally fired on. A successful replay proves the event log is
+// sufficient to reconstruct a run frame-accurately, not just a doc claim.
+func (g *Game) Replay(snap save.Snapshot) {
+	events := make([]save.InputEvent, len(snap.Events))
+	copy(events, snap.Events)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Tick < events[j].Tick })
+
+	redrawCh := g.redrawCh
+	fresh := newGame(snap.Seed, g.dispatcher.Bindings)
+	fresh.redrawCh = redrawCh
+	*g = *fresh
+
+	idx := 0
+	applyDue := func() {
+		for idx < len(events) && events[idx].Tick == g.tickCount {
+			g.applyReplayEvent(events[idx])
+			idx++
 		}
+	}
 
-		newPos := [2]int{nx, ny}
-		origPos := [2]int{cell.X, cell.Y}
+	applyDue()
+	for g.tickCount < snap.Tick {
+		g.tick()
+		applyDue()
+	}
+	g.markDirty()
+}
 
-		if !newPositions[newPos] {
-			newPositions[newPos] = true
-			newCells = append(newCells, Cell{X: nx, Y: ny, Type: cell.Type})
-		} else if !newPositions[origPos] {
-			newPositions[origPos] = true
-			newCells = append(newCells, cell)
+// applyReplayEvent re-applies one recorded InputEvent during Replay,
+// using the same systems live input would have gone through.
+func (g *Game) applyReplayEvent(ev save.InputEvent) {
+	switch ev.Kind {
+	case "place":
+		shape := system.BrushShape(ev.BrushShape)
+		if ev.Line {
+			g.inputSystem.PlaceLine(g.world, ev.X0, ev.Y0, ev.X, ev.Y, ev.BrushRadius, shape, component.LifeType(ev.LifeType))
 		} else {
-			newCells = append(newCells, cell)
-			newPositions[origPos] = true
+			g.inputSystem.PlaceBrush(g.world, ev.X, ev.Y, ev.BrushRadius, shape, component.LifeType(ev.LifeType))
 		}
+	case "speed":
+		g.gameSpeed = ev.GameSpeed
+		g.lastSpeed = ev.GameSpeed
+	case "rulePreset":
+		g.rulePreset = ev.Preset
+		g.ruleSystem = system.NewSimulatorForPreset(g.rulePreset, g.rng)
 	}
-
-	g.occupied = newPositions
-	g.cells = newCells
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
 	screen.Fill(color.Black)
 
-	screenW, screenH := ebiten.WindowSize()
-	cx, cy := float64(screenW)/2, float64(screenH)/2
-
-	lifeCount := 0
-	zombieCount := 0
-
-	for _, cell := range g.cells {
-		x := float64(cell.X * cellSize)
-		y := float64(cell.Y * cellSize)
-		screenX := (x - g.cameraX)*g.zoom + cx
-		screenY := (y - g.cameraY)*g.zoom + cy
-
-		if screenX >= 0 && screenX < float64(screenW) && screenY >= 0 && screenY < float64(screenH) {
-			var col color.Color
-			switch cell.Type {
-			case Life:
-				col = color.White
-				lifeCount++
-			case Zombie:
-				col = color.RGBA{0, 255, 0, 255}
-				zombieCount++
-			}
+	if g.gameOver {
+		g.drawGameOver(screen)
+		return
+	}
 
-			// Scale and center cell size based on zoom
-			size := math.Max(1.0, float64(cellSize)*g.zoom)
-			offset := (size - float64(cellSize)) / 2
-			ebitenutil.DrawRect(screen, screenX-offset, screenY-offset, size, size, col)
-		}
+	screenW, screenH := ebiten.WindowSize()
+	cam := system.Camera{
+		X: g.cameraX, Y: g.cameraY, Zoom: g.zoom,
+		ScreenW: screenW, ScreenH: screenH,
+		CellSize: cellSize,
 	}
+	counts := g.renderSystem.Draw(screen, g.world, cam)
+	lifeCount, zombieCount, foodCount := counts[component.Life], counts[component.Zombie], counts[component.Food]
 
-	info := fmt.Sprintf("FPS: %.2f  Zoom: %.2fx  Speed: %.2fx  Total: %d  Life: %d  Zombie: %d  [1:Life 2:Zombie]  Current: %s",
-		ebiten.CurrentTPS(), g.zoom, g.gameSpeed, len(g.cells), lifeCount, zombieCount, g.placeType)
+	info := fmt.Sprintf("FPS: %.2f  Zoom: %.2fx  Speed: %.2fx  Total: %d  Life: %d  Zombie: %d  Food: %d  Rules: %s  [1:Life 2:Zombie]  Current: %s",
+		ebiten.CurrentTPS(), g.zoom, g.gameSpeed, len(g.world.Positions), lifeCount, zombieCount, foodCount, g.ruleSystem.Name(), g.placeType)
 	ebitenutil.DebugPrintAt(screen, info, 10, 10)
 
 	if g.showUI {
 		uiColor := color.RGBA{50, 50, 50, 200}
-		panelWidth := 250
-		panelHeight := 160
-		ebitenutil.DrawRect(screen, 10, 30, float64(panelWidth), float64(panelHeight), uiColor)
+		ebitenutil.DrawRect(screen, 10, 30, g.panelWidth, g.panelHeight, uiColor)
 		ebitenutil.DebugPrintAt(screen, "[TAB] Toggle UI", 20, 40)
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("[Q/E or Scroll] Zoom: %.2fx", g.zoom), 20, 60)
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("[←/→] Speed: %.1fx", g.gameSpeed), 20, 80)
 		ebitenutil.DebugPrintAt(screen, "[1] Place Life  [2] Place Zombie", 20, 100)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Current: %s", g.placeType), 20, 120)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Life: %d  Zombie: %d", lifeCount, zombieCount), 20, 140)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Current: %s  Brush: %d %s (shift-drag: line)", g.placeType, g.brushRadius, g.brushShapeName()), 20, 120)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Life: %d  Zombie: %d  Food: %d", lifeCount, zombieCount, foodCount), 20, 140)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("[P] Rules: %s", g.ruleSystem.Name()), 20, 160)
+		ebitenutil.DebugPrintAt(screen, "[F5] Save  [F9] Load  (JSON)  [F6] Save  [F10] Load  (gob)  [R] Reset to seed", 20, 180)
+		ebitenutil.DebugPrintAt(screen, "[ [ / ] ] Brush size  [B] Brush shape", 20, 200)
+
+		lifeAvgHealth, lifeAvgAge := g.speciesAverages(component.Life)
+		zombieAvgHealth, zombieAvgAge := g.speciesAverages(component.Zombie)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Life avg health/age: %.1f / %.1f", lifeAvgHealth, lifeAvgAge), 20, 220)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Zombie avg health/age: %.1f / %.1f", zombieAvgHealth, zombieAvgAge), 20, 240)
+	}
+
+	g.drawPopulationGraph(screen, screenW)
+}
+
+// speciesAverages returns the mean Health.Current and Age.Ticks across
+// every live entity of type t, or zero if none have those components.
+// Under the CA presets no entity has an Age, and only Zombies have a
+// Health, which RuleSystem counts down as ticks left before starving.
+func (g *Game) speciesAverages(t component.LifeType) (avgHealth, avgAge float64) {
+	var totalHealth, totalAge, count int
+	for e, species := range g.world.Species {
+		if species.Type != t {
+			continue
+		}
+		count++
+		if health, ok := g.world.Healths[e]; ok {
+			totalHealth += health.Current
+		}
+		if age, ok := g.world.Ages[e]; ok {
+			totalAge += age.Ticks
+		}
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return float64(totalHealth) / float64(count), float64(totalAge) / float64(count)
+}
+
+// drawPopulationGraph renders a small line graph of recent per-species
+// population in the top-right corner, with the samples spread evenly
+// across the graph's width.
+func (g *Game) drawPopulationGraph(screen *ebiten.Image, screenW int) {
+	if len(g.popHistory) < 2 {
+		return
+	}
+
+	const (
+		graphW = 150
+		graphH = 80
+	)
+	originX := float64(screenW) - graphW - 10
+	originY := 10.0
+
+	maxPop := 1
+	for _, s := range g.popHistory {
+		for _, v := range []int{s.life, s.zombie, s.food} {
+			if v > maxPop {
+				maxPop = v
+			}
+		}
+	}
+
+	ebitenutil.DrawRect(screen, originX, originY, graphW, graphH, color.RGBA{20, 20, 20, 180})
+
+	plot := func(col color.Color, sample func(popSample) int) {
+		lastIdx := float64(len(g.popHistory) - 1)
+		for i := 1; i < len(g.popHistory); i++ {
+			x0 := originX + float64(i-1)/lastIdx*graphW
+			x1 := originX + float64(i)/lastIdx*graphW
+			y0 := originY + graphH - float64(sample(g.popHistory[i-1]))/float64(maxPop)*graphH
+			y1 := originY + graphH - float64(sample(g.popHistory[i]))/float64(maxPop)*graphH
+			ebitenutil.DrawLine(screen, x0, y0, x1, y1, col)
+		}
 	}
+	plot(color.White, func(s popSample) int { return s.life })
+	plot(color.RGBA{0, 200, 0, 255}, func(s popSample) int { return s.zombie })
+	plot(color.RGBA{160, 110, 40, 255}, func(s popSample) int { return s.food })
+}
+
+// drawGameOver renders the population-collapse screen shown once a
+// species' population hits zero. Pressing Enter starts a fresh game.
+func (g *Game) drawGameOver(screen *ebiten.Image) {
+	screenW, screenH := ebiten.WindowSize()
+	ebitenutil.DrawRect(screen, 0, 0, float64(screenW), float64(screenH), color.RGBA{20, 0, 0, 255})
+
+	cx, cy := screenW/2-120, screenH/2
+	ebitenutil.DebugPrintAt(screen, "GAME OVER", cx, cy-20)
+	ebitenutil.DebugPrintAt(screen, g.gameOverMsg, cx-60, cy)
+	ebitenutil.DebugPrintAt(screen, "[Enter] Restart", cx, cy+20)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -273,9 +698,45 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 }
 
 func main() {
+	loadPath := flag.String("load", "", "snapshot file to load at startup")
+	replayPath := flag.String("replay", "", "snapshot file to reconstruct at startup by replaying its seed and event log, instead of loading its stored board")
+	seedFlag := flag.Int64("seed", 0, "RNG seed (0 picks one from the current time)")
+	keyBindingsPath := flag.String("keybindings", "", "JSON file of action -> key overrides")
+	flag.Parse()
+
+	seed := *seedFlag
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	bindings := input.DefaultBindings()
+	if *keyBindingsPath != "" {
+		loaded, err := input.LoadBindings(*keyBindingsPath)
+		if err != nil {
+			log.Fatalf("failed to load key bindings %s: %v", *keyBindingsPath, err)
+		}
+		bindings = loaded
+	}
+	game := NewGameWithBindings(seed, bindings)
+
+	if *loadPath != "" {
+		snap, err := save.LoadJSON(*loadPath)
+		if err != nil {
+			log.Fatalf("failed to load %s: %v", *loadPath, err)
+		}
+		game.LoadSnapshot(snap)
+	}
+	if *replayPath != "" {
+		snap, err := save.LoadJSON(*replayPath)
+		if err != nil {
+			log.Fatalf("failed to load %s: %v", *replayPath, err)
+		}
+		game.Replay(snap)
+	}
+
 	ebiten.SetWindowSize(initialWidth, initialHeight)
 	ebiten.SetWindowTitle("Lifes Sandbox — F11 for Fullscreen")
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
 }
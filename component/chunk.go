@@ -0,0 +1,31 @@
+package component
+
+// ChunkSize is the width and height, in cells, of one spatial chunk.
+// Keeping chunks at a fixed size lets systems cheaply tell which
+// regions of an otherwise unbounded world currently hold anything.
+const ChunkSize = 64
+
+// Chunk tracks which entities currently occupy one ChunkSize x ChunkSize
+// region, so systems that only care about "where is there activity" —
+// RuleSystem's tick, RenderSystem's viewport culling — don't have to
+// walk the full entity set to find out.
+type Chunk struct {
+	Entities map[Entity]bool
+}
+
+func newChunk() *Chunk {
+	return &Chunk{Entities: make(map[Entity]bool)}
+}
+
+// ChunkCoord returns which chunk index along one axis contains cell v.
+func ChunkCoord(v int) int {
+	if v >= 0 {
+		return v / ChunkSize
+	}
+	return (v+1)/ChunkSize - 1
+}
+
+// ChunkKey returns the chunk coordinate containing world cell (x, y).
+func ChunkKey(x, y int) [2]int {
+	return [2]int{ChunkCoord(x), ChunkCoord(y)}
+}
@@ -0,0 +1,63 @@
+// Package component holds the plain-data components shared by every
+// system in the simulation. Components carry no behavior; systems in
+// the sibling system package interpret them.
+package component
+
+import "image/color"
+
+// LifeType identifies which species an entity's Species component
+// belongs to.
+type LifeType string
+
+const (
+	Life   LifeType = "life"
+	Zombie LifeType = "zombie"
+	Dying  LifeType = "dying"
+	Dead   LifeType = "dead"
+	Food   LifeType = "food"
+)
+
+// Position is an entity's location in world (cell, not pixel) space.
+type Position struct {
+	X, Y int
+}
+
+// Species marks which LifeType an entity is and is what RuleSystem
+// keys its transition rules on.
+type Species struct {
+	Type LifeType
+}
+
+// Health tracks an entity's remaining and maximum hit points.
+type Health struct {
+	Current, Max int
+}
+
+// Movable lets MovementSystem relocate an entity; Speed is in world
+// cells per tick.
+type Movable struct {
+	Speed float64
+}
+
+// Sprite is the color an entity is rendered with.
+type Sprite struct {
+	Color color.Color
+}
+
+// Age counts how many ticks an entity has existed. EcosystemSystem uses
+// it to kill Life off once it outlives its natural lifespan.
+type Age struct {
+	Ticks int
+}
+
+// Energy fuels reproduction under EcosystemSystem: Life gains it by
+// foraging empty neighboring tiles and spends it to spawn offspring.
+type Energy struct {
+	Current int
+}
+
+// Infection counts down the delay before a bitten Life entity turns
+// into a Zombie.
+type Infection struct {
+	TicksRemaining int
+}
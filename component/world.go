@@ -0,0 +1,149 @@
+package component
+
+// Entity is an opaque handle into a World's component maps. Entities
+// carry no data themselves; all state lives in the component tables.
+type Entity int
+
+// World owns every entity's components. Systems add, remove, and move
+// entities through the methods below rather than writing Positions
+// directly, so the Chunks spatial index never drifts out of sync with
+// Positions.
+type World struct {
+	nextEntity Entity
+
+	Positions  map[Entity]*Position
+	Species    map[Entity]*Species
+	Healths    map[Entity]*Health
+	Movables   map[Entity]*Movable
+	Sprites    map[Entity]*Sprite
+	Ages       map[Entity]*Age
+	Energies   map[Entity]*Energy
+	Infections map[Entity]*Infection
+
+	// Chunks indexes live entities by the ChunkSize x ChunkSize region
+	// they fall in. A key is only present while its chunk is non-empty.
+	Chunks map[[2]int]*Chunk
+}
+
+// NewWorld returns an empty World ready to accept entities.
+func NewWorld() *World {
+	return &World{
+		Positions:  make(map[Entity]*Position),
+		Species:    make(map[Entity]*Species),
+		Healths:    make(map[Entity]*Health),
+		Movables:   make(map[Entity]*Movable),
+		Sprites:    make(map[Entity]*Sprite),
+		Ages:       make(map[Entity]*Age),
+		Energies:   make(map[Entity]*Energy),
+		Infections: make(map[Entity]*Infection),
+		Chunks:     make(map[[2]int]*Chunk),
+	}
+}
+
+// Spawn allocates a new entity at pos with the given species and
+// registers it in the chunk index.
+func (w *World) Spawn(pos Position, species Species) Entity {
+	e := w.nextEntity
+	w.nextEntity++
+	w.Positions[e] = &Position{X: pos.X, Y: pos.Y}
+	w.Species[e] = &Species{Type: species.Type}
+	w.addToChunk(e, pos)
+	return e
+}
+
+// NewEntity allocates a fresh, component-less Entity. Callers that add
+// a Position afterward must call addToChunk themselves by going through
+// Move instead of writing w.Positions directly.
+func (w *World) NewEntity() Entity {
+	e := w.nextEntity
+	w.nextEntity++
+	return e
+}
+
+// Remove deletes every component associated with e and drops it from
+// the chunk index.
+func (w *World) Remove(e Entity) {
+	if pos, ok := w.Positions[e]; ok {
+		w.removeFromChunk(e, *pos)
+	}
+	delete(w.Positions, e)
+	delete(w.Species, e)
+	delete(w.Healths, e)
+	delete(w.Movables, e)
+	delete(w.Sprites, e)
+	delete(w.Ages, e)
+	delete(w.Energies, e)
+	delete(w.Infections, e)
+}
+
+// Move relocates e to pos, creating its Position component on first use
+// and keeping the chunk index in sync either way.
+func (w *World) Move(e Entity, pos Position) {
+	if old, ok := w.Positions[e]; ok {
+		w.removeFromChunk(e, *old)
+		old.X, old.Y = pos.X, pos.Y
+	} else {
+		w.Positions[e] = &Position{X: pos.X, Y: pos.Y}
+	}
+	w.addToChunk(e, pos)
+}
+
+func (w *World) addToChunk(e Entity, pos Position) {
+	key := ChunkKey(pos.X, pos.Y)
+	c, ok := w.Chunks[key]
+	if !ok {
+		c = newChunk()
+		w.Chunks[key] = c
+	}
+	c.Entities[e] = true
+}
+
+func (w *World) removeFromChunk(e Entity, pos Position) {
+	key := ChunkKey(pos.X, pos.Y)
+	c, ok := w.Chunks[key]
+	if !ok {
+		return
+	}
+	delete(c.Entities, e)
+	if len(c.Entities) == 0 {
+		delete(w.Chunks, key)
+	}
+}
+
+// EntityAt returns the entity occupying pos, if any, by checking only
+// the entities in pos's chunk rather than scanning the whole world.
+func (w *World) EntityAt(pos Position) (Entity, bool) {
+	c, ok := w.Chunks[ChunkKey(pos.X, pos.Y)]
+	if !ok {
+		return 0, false
+	}
+	for e := range c.Entities {
+		if p := w.Positions[e]; p != nil && *p == pos {
+			return e, true
+		}
+	}
+	return 0, false
+}
+
+// CountSpecies returns how many live entities have a Species component
+// of type t.
+func (w *World) CountSpecies(t LifeType) int {
+	count := 0
+	for _, s := range w.Species {
+		if s.Type == t {
+			count++
+		}
+	}
+	return count
+}
+
+// ActiveChunkKeys returns the keys of every chunk that currently holds
+// at least one entity. RuleSystem and EcosystemSystem use this to skip
+// empty regions of an otherwise unbounded world.
+func (w *World) ActiveChunkKeys() [][2]int {
+	keys := make([][2]int, 0, len(w.Chunks))
+	for key := range w.Chunks {
+		keys = append(keys, key)
+	}
+	return keys
+}
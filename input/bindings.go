@@ -0,0 +1,122 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action identifies a rebindable game command, independent of whichever
+// physical key currently triggers it.
+type Action string
+
+const (
+	ActionZoomIn           Action = "zoomIn"
+	ActionZoomOut          Action = "zoomOut"
+	ActionPanUp            Action = "panUp"
+	ActionPanDown          Action = "panDown"
+	ActionPanLeft          Action = "panLeft"
+	ActionPanRight         Action = "panRight"
+	ActionSpeedUp          Action = "speedUp"
+	ActionSpeedDown        Action = "speedDown"
+	ActionPlaceLife        Action = "placeLife"
+	ActionPlaceZombie      Action = "placeZombie"
+	ActionToggleUI         Action = "toggleUI"
+	ActionToggleFullscreen Action = "toggleFullscreen"
+	ActionCyclePreset      Action = "cyclePreset"
+	ActionSave             Action = "save"
+	ActionLoad             Action = "load"
+	ActionSaveCompact      Action = "saveCompact"
+	ActionLoadCompact      Action = "loadCompact"
+	ActionReset            Action = "reset"
+	ActionBrushGrow        Action = "brushGrow"
+	ActionBrushShrink      Action = "brushShrink"
+	ActionToggleBrushShape Action = "toggleBrushShape"
+)
+
+// edgeActions fire a KeyPressEvent only on the up-to-down transition.
+// Every other action fires every frame its key is held, matching how
+// the camera/speed controls have always behaved.
+var edgeActions = map[Action]bool{
+	ActionToggleFullscreen: true,
+	ActionCyclePreset:      true,
+	ActionSave:             true,
+	ActionLoad:             true,
+	ActionSaveCompact:      true,
+	ActionLoadCompact:      true,
+	ActionReset:            true,
+	ActionBrushGrow:        true,
+	ActionBrushShrink:      true,
+	ActionToggleBrushShape: true,
+}
+
+// KeyBindings maps each Action to the physical key that triggers it.
+type KeyBindings map[Action]ebiten.Key
+
+// DefaultBindings returns the bindings this game has always shipped
+// with, used whenever no config file is given.
+func DefaultBindings() KeyBindings {
+	return KeyBindings{
+		ActionZoomIn:           ebiten.KeyQ,
+		ActionZoomOut:          ebiten.KeyE,
+		ActionPanUp:            ebiten.KeyW,
+		ActionPanDown:          ebiten.KeyS,
+		ActionPanLeft:          ebiten.KeyA,
+		ActionPanRight:         ebiten.KeyD,
+		ActionSpeedUp:          ebiten.KeyArrowRight,
+		ActionSpeedDown:        ebiten.KeyArrowLeft,
+		ActionPlaceLife:        ebiten.Key1,
+		ActionPlaceZombie:      ebiten.Key2,
+		ActionToggleUI:         ebiten.KeyTab,
+		ActionToggleFullscreen: ebiten.KeyF11,
+		ActionCyclePreset:      ebiten.KeyP,
+		ActionSave:             ebiten.KeyF5,
+		ActionLoad:             ebiten.KeyF9,
+		ActionSaveCompact:      ebiten.KeyF6,
+		ActionLoadCompact:      ebiten.KeyF10,
+		ActionReset:            ebiten.KeyR,
+		ActionBrushGrow:        ebiten.KeyRightBracket,
+		ActionBrushShrink:      ebiten.KeyLeftBracket,
+		ActionToggleBrushShape: ebiten.KeyB,
+	}
+}
+
+// keyNames lets a config file name a key instead of spelling out
+// Ebiten's constant, and is only as large as the keys this game uses.
+var keyNames = map[string]ebiten.Key{
+	"Q": ebiten.KeyQ, "E": ebiten.KeyE, "W": ebiten.KeyW, "A": ebiten.KeyA,
+	"S": ebiten.KeyS, "D": ebiten.KeyD, "P": ebiten.KeyP, "R": ebiten.KeyR,
+	"Tab": ebiten.KeyTab, "F5": ebiten.KeyF5, "F9": ebiten.KeyF9, "F11": ebiten.KeyF11,
+	"F6": ebiten.KeyF6, "F10": ebiten.KeyF10, "B": ebiten.KeyB,
+	"1": ebiten.Key1, "2": ebiten.Key2,
+	"ArrowLeft": ebiten.KeyArrowLeft, "ArrowRight": ebiten.KeyArrowRight,
+	"LeftBracket": ebiten.KeyLeftBracket, "RightBracket": ebiten.KeyRightBracket,
+}
+
+// LoadBindings reads a JSON object of Action -> key name (see keyNames)
+// from path and overlays it onto DefaultBindings, so a config file only
+// needs to list the keys it's rebinding.
+func LoadBindings(path string) (KeyBindings, error) {
+	bindings := DefaultBindings()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var overrides map[Action]string
+	if err := json.NewDecoder(f).Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("input: decoding %s: %w", path, err)
+	}
+	for action, keyName := range overrides {
+		key, ok := keyNames[keyName]
+		if !ok {
+			return nil, fmt.Errorf("input: %s: unknown key %q for action %q", path, keyName, action)
+		}
+		bindings[action] = key
+	}
+	return bindings, nil
+}
@@ -0,0 +1,75 @@
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Dispatcher polls Ebiten's input state once per frame and emits typed
+// events to every registered Handler, so Game's Update only has to
+// answer "what does a zoomIn event mean" rather than "is Q or the wheel
+// being held right now".
+type Dispatcher struct {
+	Bindings KeyBindings
+
+	handlers    []Handler
+	prevPressed map[Action]bool
+	prevWidth   int
+	prevHeight  int
+}
+
+// NewDispatcher returns a Dispatcher that polls under bindings. Its
+// first Poll always emits a WindowResizeEvent, since it has no prior
+// screen size to compare against — handlers can use that to size
+// anything that depends on screen dimensions instead of hardcoding it.
+func NewDispatcher(bindings KeyBindings) *Dispatcher {
+	return &Dispatcher{
+		Bindings:    bindings,
+		prevPressed: make(map[Action]bool),
+	}
+}
+
+// Subscribe registers h to receive every event future Polls emit.
+func (d *Dispatcher) Subscribe(h Handler) {
+	d.handlers = append(d.handlers, h)
+}
+
+func (d *Dispatcher) emit(e Event) {
+	for _, h := range d.handlers {
+		h(e)
+	}
+}
+
+// Poll reads the current frame's input state and dispatches one event
+// per currently-relevant action, plus mouse position, mouse-down, and
+// window-resize events.
+func (d *Dispatcher) Poll(screenW, screenH int) {
+	mx, my := ebiten.CursorPosition()
+	d.emit(MouseMoveEvent{X: float64(mx), Y: float64(my)})
+
+	for action, key := range d.Bindings {
+		pressed := ebiten.IsKeyPressed(key)
+		if edgeActions[action] {
+			if pressed && !d.prevPressed[action] {
+				d.emit(KeyPressEvent{Action: action})
+			}
+		} else if pressed {
+			d.emit(KeyPressEvent{Action: action})
+		}
+		d.prevPressed[action] = pressed
+	}
+
+	_, scrollY := ebiten.Wheel()
+	if scrollY > 0 {
+		d.emit(KeyPressEvent{Action: ActionZoomIn})
+	} else if scrollY < 0 {
+		d.emit(KeyPressEvent{Action: ActionZoomOut})
+	}
+
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		shift := ebiten.IsKeyPressed(ebiten.KeyShiftLeft) || ebiten.IsKeyPressed(ebiten.KeyShiftRight)
+		d.emit(MouseDownEvent{X: float64(mx), Y: float64(my), Button: ebiten.MouseButtonLeft, Shift: shift})
+	}
+
+	if screenW != d.prevWidth || screenH != d.prevHeight {
+		d.emit(WindowResizeEvent{Width: screenW, Height: screenH})
+		d.prevWidth, d.prevHeight = screenW, screenH
+	}
+}
@@ -0,0 +1,56 @@
+// Package input turns Ebiten's raw, polled key/mouse state into typed
+// events dispatched to whatever in the game registers as a handler, so
+// Game doesn't have to carry one giant block of ebiten.IsKeyPressed
+// checks and callers can rebind physical keys without touching it.
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Event is the common interface satisfied by every event this package
+// emits. It carries no behavior; it exists so Handler can accept any of
+// them and the receiver can type-switch on the concrete type.
+type Event interface {
+	isEvent()
+}
+
+// MouseMoveEvent reports the cursor's current screen position. It fires
+// every frame regardless of whether the cursor moved.
+type MouseMoveEvent struct {
+	X, Y float64
+}
+
+func (MouseMoveEvent) isEvent() {}
+
+// MouseDownEvent reports that a mouse button is currently held, along
+// with the modifier state needed to pick a placement shape. It fires
+// every frame the button is held, not just on the initial press, so a
+// handler can paint continuously while dragging.
+type MouseDownEvent struct {
+	X, Y   float64
+	Button ebiten.MouseButton
+	Shift  bool
+}
+
+func (MouseDownEvent) isEvent() {}
+
+// KeyPressEvent reports that an Action's bound key is active this
+// frame. Continuous actions (camera pan, zoom, speed) fire every frame
+// their key is held; edge-triggered actions (see edgeActions) fire only
+// on the frame the key transitions from up to down.
+type KeyPressEvent struct {
+	Action Action
+}
+
+func (KeyPressEvent) isEvent() {}
+
+// WindowResizeEvent reports that Ebiten's layout produced a new screen
+// size. It fires once on the frame the size changes.
+type WindowResizeEvent struct {
+	Width, Height int
+}
+
+func (WindowResizeEvent) isEvent() {}
+
+// Handler receives every event a Dispatcher emits. Callers type-switch
+// on the concrete Event to decide what to do with it.
+type Handler func(Event)
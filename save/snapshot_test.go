@@ -0,0 +1,61 @@
+package save
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testSnapshot() Snapshot {
+	return Snapshot{
+		Seed:        42,
+		Tick:        7,
+		WorldWidth:  4000,
+		WorldHeight: 4000,
+		CellSize:    10,
+		CameraX:     123.5,
+		CameraY:     456.25,
+		Zoom:        1.5,
+		RulePreset:  2,
+		Cells: []Cell{
+			{X: 1, Y: 2, Type: "life", Health: 10, Age: 3, Energy: 4, InfectionTicks: 0},
+			{X: 3, Y: 4, Type: "zombie", Health: 8, Age: 0, Energy: 0, InfectionTicks: 2},
+		},
+		Events: []InputEvent{
+			{Tick: 1, Kind: "place", X: 1, Y: 2, LifeType: "life"},
+			{Tick: 3, Kind: "speed", GameSpeed: 2.0},
+		},
+	}
+}
+
+func TestSaveLoadJSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	want := testSnapshot()
+
+	if err := SaveJSON(path, want); err != nil {
+		t.Fatalf("SaveJSON: %v", err)
+	}
+	got, err := LoadJSON(path)
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round-tripped snapshot differs:\nwant %+v\ngot  %+v", want, got)
+	}
+}
+
+func TestSaveLoadGobRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.gob")
+	want := testSnapshot()
+
+	if err := SaveGob(path, want); err != nil {
+		t.Fatalf("SaveGob: %v", err)
+	}
+	got, err := LoadGob(path)
+	if err != nil {
+		t.Fatalf("LoadGob: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round-tripped snapshot differs:\nwant %+v\ngot  %+v", want, got)
+	}
+}
@@ -0,0 +1,254 @@
+package system
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+const (
+	// lifeEnergyFromForaging is how much Energy a Life entity gains each
+	// tick it has at least one empty neighboring tile to forage.
+	lifeEnergyFromForaging = 1
+	// lifeReproduceThreshold is the Energy a Life entity needs before it
+	// spends lifeReproduceCost of it to spawn offspring into an empty
+	// neighboring tile.
+	lifeReproduceThreshold = 8
+	lifeReproduceCost      = 5
+	// lifeMaxAge is how many ticks a Life entity survives before it dies
+	// of old age, regardless of its Energy.
+	lifeMaxAge = 500
+
+	// lifeStartHealth is both a freshly-spawned Life entity's Health and
+	// the ceiling it regenerates toward while foraging successfully.
+	lifeStartHealth = 10
+	// lifeHealthLossPerTick is how much Health a Life entity loses on a
+	// tick where it finds no empty neighboring tile to forage.
+	lifeHealthLossPerTick = 1
+	// lifeHealthRegenPerTick is how much Health a successful foraging
+	// tick restores, up to lifeStartHealth.
+	lifeHealthRegenPerTick = 1
+
+	// zombieStartHealth is both a freshly-turned Zombie's Health and
+	// what it's restored to after it feeds.
+	zombieStartHealth       = 10
+	zombieHealthLossPerTick = 1
+	// zombieInfectionDelay is how many ticks a bitten Life entity has
+	// left before it turns into a Zombie.
+	zombieInfectionDelay = 5
+
+	// foodRegrowChance is the probability, each tick, that a Food entity
+	// spreads into one empty neighboring tile.
+	foodRegrowChance = 0.02
+)
+
+// spawnRequest defers a World.Spawn until after a tick's decisions have
+// all been made against a consistent pre-tick spatial hash, the same
+// way RuleSystem.spawnBirths does.
+type spawnRequest struct {
+	pos  component.Position
+	kind component.LifeType
+}
+
+// EcosystemSystem replaces neighbor-count survive/birth rules with
+// per-species behavior driven by each entity's Health, Age, Energy, and
+// Infection components: Life forages and reproduces, dying of old age;
+// Zombie starves unless it infects an adjacent Life; Food regrows
+// stochastically into empty neighboring tiles.
+type EcosystemSystem struct {
+	rng *rand.Rand
+}
+
+// NewEcosystemSystem returns an EcosystemSystem whose Food regrowth
+// rolls against rng, so a seeded replay reproduces it exactly.
+func NewEcosystemSystem(rng *rand.Rand) *EcosystemSystem {
+	return &EcosystemSystem{rng: rng}
+}
+
+// Name satisfies Simulator.
+func (s *EcosystemSystem) Name() string {
+	return "Ecosystem"
+}
+
+// MaxHealthFor returns the max Health a freshly-spawned entity of type t
+// starts with, so a caller reconstructing a Health component from a
+// saved Current value (which may be mid-damage) doesn't have to assume
+// Max equals Current. ok is false for a type with no Health semantics.
+func MaxHealthFor(t component.LifeType) (max int, ok bool) {
+	switch t {
+	case component.Life:
+		return lifeStartHealth, true
+	case component.Zombie:
+		return zombieStartHealth, true
+	default:
+		return 0, false
+	}
+}
+
+func (s *EcosystemSystem) Update(w *component.World) {
+	hash := buildSpatialHash(w)
+	species := snapshotSpecies(w)
+
+	var deaths []component.Entity
+	var spawns []spawnRequest
+	var bites []component.Entity
+
+	// Visiting entities in ascending Entity order rather than map order
+	// keeps which Food gets which rng roll, and which of two competing
+	// spawns wins a tile, the same on every run of the same seed.
+	var entities []component.Entity
+	for _, key := range w.ActiveChunkKeys() {
+		for e := range w.Chunks[key].Entities {
+			entities = append(entities, e)
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i] < entities[j] })
+
+	for _, e := range entities {
+		sp, ok := w.Species[e]
+		if !ok {
+			continue
+		}
+		pos, ok := w.Positions[e]
+		if !ok {
+			continue
+		}
+
+		age := w.Ages[e]
+		if age == nil {
+			age = &component.Age{}
+			w.Ages[e] = age
+		}
+		age.Ticks++
+
+		switch sp.Type {
+		case component.Life:
+			s.updateLife(w, hash, e, *pos, age, &deaths, &spawns)
+		case component.Zombie:
+			s.updateZombie(w, hash, species, e, *pos, &deaths, &bites)
+		case component.Food:
+			s.updateFood(hash, *pos, &spawns)
+		}
+	}
+
+	for _, e := range deaths {
+		w.Remove(e)
+	}
+	for _, sp := range spawns {
+		if _, occupied := w.EntityAt(sp.pos); occupied {
+			continue
+		}
+		w.Spawn(sp.pos, component.Species{Type: sp.kind})
+	}
+	// Applying bites only after every entity has made this tick's
+	// decisions means updateLife always sees the infection state as it
+	// stood at the start of the tick, never one a Zombie processed
+	// earlier in the same chunk.Entities iteration just wrote — so a
+	// freshly-bitten Life's countdown can't depend on Go's unspecified
+	// map iteration order.
+	for _, prey := range bites {
+		if w.Infections[prey] != nil {
+			continue
+		}
+		if preySpecies, ok := w.Species[prey]; !ok || preySpecies.Type != component.Life {
+			continue
+		}
+		w.Infections[prey] = &component.Infection{TicksRemaining: zombieInfectionDelay}
+	}
+}
+
+func (s *EcosystemSystem) updateLife(
+	w *component.World,
+	hash map[[2]int]component.Entity,
+	e component.Entity,
+	pos component.Position,
+	age *component.Age,
+	deaths *[]component.Entity,
+	spawns *[]spawnRequest,
+) {
+	if age.Ticks > lifeMaxAge {
+		*deaths = append(*deaths, e)
+		return
+	}
+
+	if infection := w.Infections[e]; infection != nil {
+		infection.TicksRemaining--
+		if infection.TicksRemaining <= 0 {
+			w.Species[e].Type = component.Zombie
+			delete(w.Infections, e)
+			w.Healths[e] = &component.Health{Current: zombieStartHealth, Max: zombieStartHealth}
+		}
+		return
+	}
+
+	health := w.Healths[e]
+	if health == nil {
+		health = &component.Health{Current: lifeStartHealth, Max: lifeStartHealth}
+		w.Healths[e] = health
+	}
+
+	empty, hasEmpty := emptyNeighbor(hash, pos)
+	if !hasEmpty {
+		health.Current -= lifeHealthLossPerTick
+		if health.Current <= 0 {
+			*deaths = append(*deaths, e)
+		}
+		return
+	}
+	health.Current = minInt(health.Current+lifeHealthRegenPerTick, health.Max)
+
+	energy := w.Energies[e]
+	if energy == nil {
+		energy = &component.Energy{}
+		w.Energies[e] = energy
+	}
+	energy.Current += lifeEnergyFromForaging
+	if energy.Current >= lifeReproduceThreshold {
+		energy.Current -= lifeReproduceCost
+		*spawns = append(*spawns, spawnRequest{pos: empty, kind: component.Life})
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (s *EcosystemSystem) updateZombie(
+	w *component.World,
+	hash map[[2]int]component.Entity,
+	species SpeciesSnapshot,
+	e component.Entity,
+	pos component.Position,
+	deaths *[]component.Entity,
+	bites *[]component.Entity,
+) {
+	health := w.Healths[e]
+	if health == nil {
+		health = &component.Health{Current: zombieStartHealth, Max: zombieStartHealth}
+		w.Healths[e] = health
+	}
+
+	if prey, ok := neighborOfType(species, hash, pos, component.Life); ok {
+		health.Current = health.Max
+		*bites = append(*bites, prey)
+		return
+	}
+
+	health.Current -= zombieHealthLossPerTick
+	if health.Current <= 0 {
+		*deaths = append(*deaths, e)
+	}
+}
+
+func (s *EcosystemSystem) updateFood(hash map[[2]int]component.Entity, pos component.Position, spawns *[]spawnRequest) {
+	if s.rng.Float64() >= foodRegrowChance {
+		return
+	}
+	if empty, ok := emptyNeighbor(hash, pos); ok {
+		*spawns = append(*spawns, spawnRequest{pos: empty, kind: component.Food})
+	}
+}
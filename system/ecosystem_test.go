@@ -0,0 +1,80 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+func TestEcosystemSystemLifeForagesAndReproduces(t *testing.T) {
+	w := component.NewWorld()
+	e := w.Spawn(component.Position{X: 10, Y: 10}, component.Species{Type: component.Life})
+
+	es := NewEcosystemSystem(rand.New(rand.NewSource(1)))
+	for i := 0; i < lifeReproduceThreshold; i++ {
+		es.Update(w)
+	}
+
+	energy, ok := w.Energies[e]
+	if !ok {
+		t.Fatalf("expected Life to gain an Energy component while foraging")
+	}
+	if energy.Current >= lifeReproduceThreshold {
+		t.Fatalf("Life should have spent its Energy on reproducing by now, got %d", energy.Current)
+	}
+	if got := w.CountSpecies(component.Life); got < 2 {
+		t.Fatalf("expected foraging Life to have reproduced at least once, got %d entities", got)
+	}
+}
+
+func TestEcosystemSystemLifeStarvesWithNoRoom(t *testing.T) {
+	w := component.NewWorld()
+	// Surround the Life entity on all 8 sides so it can never forage.
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			w.Spawn(component.Position{X: 10 + dx, Y: 10 + dy}, component.Species{Type: component.Life})
+		}
+	}
+	center, _ := w.EntityAt(component.Position{X: 10, Y: 10})
+
+	es := NewEcosystemSystem(rand.New(rand.NewSource(1)))
+	for i := 0; i < lifeStartHealth+1; i++ {
+		es.Update(w)
+	}
+
+	if _, ok := w.Positions[center]; ok {
+		t.Fatalf("boxed-in Life should have starved to death by now")
+	}
+}
+
+func TestEcosystemSystemZombieFeedsAndInfects(t *testing.T) {
+	w := component.NewWorld()
+	w.Spawn(component.Position{X: 0, Y: 0}, component.Species{Type: component.Zombie})
+	prey := w.Spawn(component.Position{X: 1, Y: 0}, component.Species{Type: component.Life})
+
+	es := NewEcosystemSystem(rand.New(rand.NewSource(1)))
+	es.Update(w)
+
+	infection, ok := w.Infections[prey]
+	if !ok {
+		t.Fatalf("expected the adjacent Life entity to become infected")
+	}
+	if infection.TicksRemaining != zombieInfectionDelay {
+		t.Fatalf("expected a fresh infection countdown of %d, got %d", zombieInfectionDelay, infection.TicksRemaining)
+	}
+}
+
+func TestEcosystemSystemZombieStarvesAlone(t *testing.T) {
+	w := component.NewWorld()
+	e := w.Spawn(component.Position{X: 0, Y: 0}, component.Species{Type: component.Zombie})
+
+	es := NewEcosystemSystem(rand.New(rand.NewSource(1)))
+	for i := 0; i < zombieStartHealth; i++ {
+		es.Update(w)
+	}
+
+	if _, ok := w.Positions[e]; ok {
+		t.Fatalf("Zombie with no prey should have starved to death by now")
+	}
+}
@@ -0,0 +1,109 @@
+package system
+
+import "github.com/quartermeat/cell_auto_port/component"
+
+// InputSystem turns already-resolved world-space placement requests
+// into new entities. Camera math and key-to-action mapping live in the
+// input package; this system only knows how to stamp cells into World,
+// clipped to the grid it was built for.
+type InputSystem struct {
+	GridWidth, GridHeight int
+}
+
+// NewInputSystem returns an InputSystem that refuses to place cells
+// outside a gridWidth x gridHeight grid (in cells, not pixels).
+func NewInputSystem(gridWidth, gridHeight int) *InputSystem {
+	return &InputSystem{GridWidth: gridWidth, GridHeight: gridHeight}
+}
+
+func (s *InputSystem) inBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < s.GridWidth && y < s.GridHeight
+}
+
+// BrushShape selects how PlaceBrush spreads cells around its center.
+type BrushShape int
+
+const (
+	BrushSquare BrushShape = iota
+	BrushCircle
+)
+
+// PlaceCell spawns a new entity of type t at (x, y) unless that cell is
+// already occupied or falls outside the grid. It reports whether an
+// entity was created. Every other placement helper in this file funnels
+// through here, so a brush or line stamped near the edge of the world
+// can never spawn a cell out of bounds.
+func (s *InputSystem) PlaceCell(w *component.World, x, y int, t component.LifeType) bool {
+	if !s.inBounds(x, y) {
+		return false
+	}
+	pos := component.Position{X: x, Y: y}
+	if _, occupied := w.EntityAt(pos); occupied {
+		return false
+	}
+	w.Spawn(pos, component.Species{Type: t})
+	return true
+}
+
+// PlaceBrush stamps every empty cell within radius of (cx, cy) under
+// shape. It reports how many entities were created, so a caller placing
+// every frame the mouse is held can tell whether anything actually
+// changed before recording an input event or redrawing.
+func (s *InputSystem) PlaceBrush(w *component.World, cx, cy, radius int, shape BrushShape, t component.LifeType) int {
+	placed := 0
+	for dx := -radius; dx <= radius; dx++ {
+		for dy := -radius; dy <= radius; dy++ {
+			if shape == BrushCircle && dx*dx+dy*dy > radius*radius {
+				continue
+			}
+			if s.PlaceCell(w, cx+dx, cy+dy, t) {
+				placed++
+			}
+		}
+	}
+	return placed
+}
+
+// PlaceLine stamps a brush at every point on the line from (x0, y0) to
+// (x1, y1), walked with Bresenham's algorithm. Stamping only the
+// current mouse position drops cells whenever the cursor moves more
+// than one grid cell between frames; walking the segment it swept since
+// the last frame closes those gaps.
+func (s *InputSystem) PlaceLine(w *component.World, x0, y0, x1, y1, radius int, shape BrushShape, t component.LifeType) int {
+	placed := 0
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	x, y := x0, y0
+	for {
+		placed += s.PlaceBrush(w, x, y, radius, shape, t)
+		if x == x1 && y == y1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+	return placed
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,26 @@
+package system
+
+import "github.com/quartermeat/cell_auto_port/component"
+
+// MovementSystem relocates entities that carry a Movable component.
+// No species currently moves under its own power — rule-driven birth
+// and death handle Life/Zombie/Dying — but this is the hook future
+// species (predators, wandering food) will plug into.
+type MovementSystem struct{}
+
+// NewMovementSystem returns a ready-to-use MovementSystem.
+func NewMovementSystem() *MovementSystem {
+	return &MovementSystem{}
+}
+
+// Update advances every Movable entity by Speed cells per tick. dt is
+// the number of ticks elapsed, matching the RuleSystem's step cadence.
+func (s *MovementSystem) Update(w *component.World, dt float64) {
+	for e, m := range w.Movables {
+		pos, ok := w.Positions[e]
+		if !ok {
+			continue
+		}
+		w.Move(e, component.Position{X: pos.X + int(m.Speed*dt), Y: pos.Y})
+	}
+}
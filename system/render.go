@@ -0,0 +1,133 @@
+package system
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+// Camera is the subset of camera/viewport state RenderSystem needs to
+// project world cells onto the screen.
+type Camera struct {
+	X, Y, Zoom       float64
+	ScreenW, ScreenH int
+	CellSize         int
+}
+
+// RenderSystem draws every entity with a Position and Species, color
+// coding it by LifeType, and reports how many of each type it drew.
+type RenderSystem struct{}
+
+// NewRenderSystem returns a ready-to-use RenderSystem.
+func NewRenderSystem() *RenderSystem {
+	return &RenderSystem{}
+}
+
+// Draw renders w's entities to screen under cam and returns the
+// per-species counts it encountered, for HUD display. It only visits
+// chunks whose cell range intersects the camera's viewport, so an
+// idle region of a million-cell world costs nothing to draw.
+func (s *RenderSystem) Draw(screen *ebiten.Image, w *component.World, cam Camera) map[component.LifeType]int {
+	counts := map[component.LifeType]int{}
+	cx, cy := float64(cam.ScreenW)/2, float64(cam.ScreenH)/2
+
+	minChunkX, minChunkY, maxChunkX, maxChunkY := cam.visibleChunkRange()
+
+	for chunkX := minChunkX; chunkX <= maxChunkX; chunkX++ {
+		for chunkY := minChunkY; chunkY <= maxChunkY; chunkY++ {
+			chunk, ok := w.Chunks[[2]int{chunkX, chunkY}]
+			if !ok {
+				continue
+			}
+
+			for e := range chunk.Entities {
+				pos, ok := w.Positions[e]
+				if !ok {
+					continue
+				}
+				species, ok := w.Species[e]
+				if !ok {
+					continue
+				}
+
+				x := float64(pos.X * cam.CellSize)
+				y := float64(pos.Y * cam.CellSize)
+				screenX := (x-cam.X)*cam.Zoom + cx
+				screenY := (y-cam.Y)*cam.Zoom + cy
+
+				if screenX < 0 || screenX >= float64(cam.ScreenW) || screenY < 0 || screenY >= float64(cam.ScreenH) {
+					continue
+				}
+
+				col := spriteColor(w, e, species.Type)
+				counts[species.Type]++
+
+				size := math.Max(1.0, float64(cam.CellSize)*cam.Zoom)
+				offset := (size - float64(cam.CellSize)) / 2
+				ebitenutil.DrawRect(screen, screenX-offset, screenY-offset, size, size, col)
+			}
+		}
+	}
+
+	return counts
+}
+
+// visibleChunkRange returns the inclusive [min, max] chunk coordinates
+// that can be seen under this camera.
+func (cam Camera) visibleChunkRange() (minX, minY, maxX, maxY int) {
+	halfW := float64(cam.ScreenW) / 2 / cam.Zoom
+	halfH := float64(cam.ScreenH) / 2 / cam.Zoom
+
+	leftCell := int(math.Floor((cam.X - halfW) / float64(cam.CellSize)))
+	rightCell := int(math.Ceil((cam.X + halfW) / float64(cam.CellSize)))
+	topCell := int(math.Floor((cam.Y - halfH) / float64(cam.CellSize)))
+	bottomCell := int(math.Ceil((cam.Y + halfH) / float64(cam.CellSize)))
+
+	return component.ChunkCoord(leftCell), component.ChunkCoord(topCell),
+		component.ChunkCoord(rightCell), component.ChunkCoord(bottomCell)
+}
+
+func spriteColor(w *component.World, e component.Entity, t component.LifeType) color.Color {
+	if sprite, ok := w.Sprites[e]; ok {
+		return sprite.Color
+	}
+	switch t {
+	case component.Life:
+		healthFrac := 1.0
+		if health := w.Healths[e]; health != nil && health.Max > 0 {
+			healthFrac = float64(health.Current) / float64(health.Max)
+		}
+		return lerpColor(color.RGBA{80, 80, 80, 255}, color.RGBA{255, 255, 255, 255}, healthFrac)
+	case component.Zombie:
+		nearDeath := 1.0
+		if health := w.Healths[e]; health != nil && health.Max > 0 {
+			nearDeath = 1 - float64(health.Current)/float64(health.Max)
+		}
+		return lerpColor(color.RGBA{200, 255, 200, 255}, color.RGBA{0, 180, 0, 255}, nearDeath)
+	case component.Food:
+		return color.RGBA{160, 110, 40, 255}
+	case component.Dying:
+		return color.RGBA{255, 150, 0, 255}
+	default:
+		return color.White
+	}
+}
+
+// lerpColor blends from a to b by t, clamped to [0, 1].
+func lerpColor(a, b color.RGBA, t float64) color.Color {
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	return color.RGBA{
+		R: uint8(float64(a.R) + (float64(b.R)-float64(a.R))*t),
+		G: uint8(float64(a.G) + (float64(b.G)-float64(a.G))*t),
+		B: uint8(float64(a.B) + (float64(b.B)-float64(a.B))*t),
+		A: 255,
+	}
+}
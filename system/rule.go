@@ -0,0 +1,172 @@
+package system
+
+import (
+	"sort"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+// RuleSystem advances the cellular-automaton simulation by one tick
+// according to a RuleSet. It builds a spatial hash of the current
+// Position/Species components each tick so every neighbor lookup is
+// O(1), then decides every entity's next state purely from that
+// snapshot — order-independent, as if double-buffered.
+type RuleSystem struct {
+	Rules RuleSet
+}
+
+// NewRuleSystem returns a RuleSystem configured with the given preset.
+func NewRuleSystem(rules RuleSet) *RuleSystem {
+	return &RuleSystem{Rules: rules}
+}
+
+// Name satisfies Simulator.
+func (s *RuleSystem) Name() string {
+	return s.Rules.Name
+}
+
+// countNeighbors reads species from a frozen SpeciesSnapshot rather than
+// the live world, so a neighbor that dies or reclassifies later in this
+// same Update still counts as whatever it was at the start of the tick.
+func (s *RuleSystem) countNeighbors(species SpeciesSnapshot, hash map[[2]int]component.Entity, pos [2]int) map[component.LifeType]int {
+	counts := map[component.LifeType]int{}
+	for _, off := range neighborOffsets {
+		npos := [2]int{pos[0] + off[0], pos[1] + off[1]}
+		if e, ok := hash[npos]; ok {
+			if t, ok := species[e]; ok {
+				counts[t]++
+			}
+		}
+	}
+	return counts
+}
+
+// Update mutates w in place: surviving entities may change Species,
+// starved or crowded-out entities are removed, and new entities are
+// born into empty cells that satisfy a BirthCounts rule. Only chunks
+// that hold at least one entity are visited, so an otherwise empty
+// world costs nothing regardless of how large worldWidth/Height are.
+func (s *RuleSystem) Update(w *component.World) {
+	hash := buildSpatialHash(w)
+	species := snapshotSpecies(w)
+
+	type transition struct {
+		entity   component.Entity
+		nextType component.LifeType
+	}
+	var transitions []transition
+	var deaths []component.Entity
+
+	for _, key := range w.ActiveChunkKeys() {
+		chunk := w.Chunks[key]
+		for e := range chunk.Entities {
+			sp, ok := w.Species[e]
+			if !ok {
+				continue
+			}
+			pos, ok := w.Positions[e]
+			if !ok {
+				continue
+			}
+			rule, ok := s.Rules.Species[sp.Type]
+			if !ok {
+				continue
+			}
+
+			counts := s.countNeighbors(species, hash, [2]int{pos.X, pos.Y})
+
+			nextType := component.LifeType("")
+			for _, reaction := range rule.Reactions {
+				if counts[reaction.TriggerType] >= reaction.MinCount {
+					nextType = reaction.BecomesType
+					break
+				}
+			}
+			if nextType == "" && rule.countMatches(rule.SurviveCounts, counts[sp.Type]) {
+				nextType = sp.Type
+			}
+
+			if sp.Type == component.Zombie {
+				health := w.Healths[e]
+				if health == nil {
+					health = &component.Health{Current: ZombieStarveTicks, Max: ZombieStarveTicks}
+					w.Healths[e] = health
+				}
+				if counts[component.Life] > 0 {
+					health.Current = health.Max
+				} else {
+					health.Current--
+				}
+				if health.Current <= 0 {
+					nextType = ""
+				}
+			}
+
+			if nextType == "" || nextType == component.Dead {
+				deaths = append(deaths, e)
+				continue
+			}
+			if nextType != sp.Type {
+				transitions = append(transitions, transition{entity: e, nextType: nextType})
+			}
+		}
+	}
+
+	for _, t := range transitions {
+		w.Species[t.entity].Type = t.nextType
+	}
+	for _, e := range deaths {
+		w.Remove(e)
+	}
+
+	s.spawnBirths(w, hash, species)
+}
+
+// spawnBirths finds empty cells adjacent to the previous tick's
+// population and births a new entity wherever a species' BirthCounts
+// rule is satisfied. species is the same pre-tick snapshot Update built,
+// so a birth still counts neighbors that died earlier in this Update.
+func (s *RuleSystem) spawnBirths(w *component.World, hash map[[2]int]component.Entity, species SpeciesSnapshot) {
+	candidateSet := map[[2]int]bool{}
+	for pos := range hash {
+		for _, off := range neighborOffsets {
+			npos := [2]int{pos[0] + off[0], pos[1] + off[1]}
+			if _, occupied := hash[npos]; !occupied {
+				candidateSet[npos] = true
+			}
+		}
+	}
+
+	// Walking candidates and species rules in a fixed order keeps births
+	// deterministic: map iteration order is randomized in Go, and a
+	// seeded replay must produce the same board every time it runs.
+	candidates := make([][2]int, 0, len(candidateSet))
+	for pos := range candidateSet {
+		candidates = append(candidates, pos)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i][0] != candidates[j][0] {
+			return candidates[i][0] < candidates[j][0]
+		}
+		return candidates[i][1] < candidates[j][1]
+	})
+
+	rules := make([]SpeciesRule, 0, len(s.Rules.Species))
+	for _, rule := range s.Rules.Species {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Type < rules[j].Type })
+
+	for _, pos := range candidates {
+		if _, occupiedNow := w.EntityAt(component.Position{X: pos[0], Y: pos[1]}); occupiedNow {
+			continue
+		}
+		counts := s.countNeighbors(species, hash, pos)
+		for _, rule := range rules {
+			if rule.countMatches(rule.BirthCounts, counts[rule.Type]) {
+				w.Spawn(component.Position{X: pos[0], Y: pos[1]}, component.Species{Type: rule.Type})
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+func spawnLife(w *component.World, x, y int) {
+	w.Spawn(component.Position{X: x, Y: y}, component.Species{Type: component.Life})
+}
+
+func TestRuleSystemConwayBirth(t *testing.T) {
+	w := component.NewWorld()
+	// A horizontal blinker: three live neighbors give the empty cell
+	// above the middle exactly 3 neighbors, which should birth it.
+	spawnLife(w, 0, 1)
+	spawnLife(w, 1, 1)
+	spawnLife(w, 2, 1)
+
+	rs := NewRuleSystem(conwayRuleSet)
+	rs.Update(w)
+
+	if _, ok := w.EntityAt(component.Position{X: 1, Y: 0}); !ok {
+		t.Fatalf("expected a birth at (1, 0)")
+	}
+}
+
+func TestRuleSystemConwayDeathByIsolation(t *testing.T) {
+	w := component.NewWorld()
+	spawnLife(w, 5, 5)
+
+	rs := NewRuleSystem(conwayRuleSet)
+	rs.Update(w)
+
+	if _, ok := w.EntityAt(component.Position{X: 5, Y: 5}); ok {
+		t.Fatalf("isolated cell should have died, but survived")
+	}
+}
+
+func TestRuleSystemConwaySurvival(t *testing.T) {
+	w := component.NewWorld()
+	// A 2x2 block is stable under Conway's rules: every cell has
+	// exactly 3 live neighbors.
+	spawnLife(w, 0, 0)
+	spawnLife(w, 1, 0)
+	spawnLife(w, 0, 1)
+	spawnLife(w, 1, 1)
+
+	rs := NewRuleSystem(conwayRuleSet)
+	rs.Update(w)
+
+	for _, pos := range []component.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}} {
+		if _, ok := w.EntityAt(pos); !ok {
+			t.Fatalf("block cell at %+v should have survived", pos)
+		}
+	}
+	if got := w.CountSpecies(component.Life); got != 4 {
+		t.Fatalf("block should stay at 4 live cells, got %d", got)
+	}
+}
@@ -0,0 +1,128 @@
+package system
+
+import (
+	"math/rand"
+
+	"github.com/quartermeat/cell_auto_port/component"
+)
+
+// SpeciesRule describes how a single LifeType behaves from one tick to
+// the next: how many same-type neighbors let an occupied cell survive,
+// how many let an empty cell give birth, and what it turns into when
+// another species crowds it out. It is distinct from component.Species,
+// which just tags an entity's current type.
+type SpeciesRule struct {
+	Type          component.LifeType
+	SurviveCounts []int
+	BirthCounts   []int
+	Reactions     []Reaction
+}
+
+// Reaction converts a cell of one species into another once it sees at
+// least MinCount neighbors of TriggerType. Reactions are checked before
+// survive/birth rules, so e.g. a Life cell swarmed by Zombies turns
+// before its own survival count is even considered.
+type Reaction struct {
+	TriggerType component.LifeType
+	MinCount    int
+	BecomesType component.LifeType
+}
+
+func (s SpeciesRule) countMatches(counts []int, n int) bool {
+	for _, c := range counts {
+		if c == n {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleSet is a named bundle of per-species rules. Presets below are
+// selectable at runtime with the [P] hotkey.
+type RuleSet struct {
+	Name    string
+	Species map[component.LifeType]SpeciesRule
+}
+
+var conwayRuleSet = RuleSet{
+	Name: "Conway",
+	Species: map[component.LifeType]SpeciesRule{
+		component.Life: {
+			Type:          component.Life,
+			SurviveCounts: []int{2, 3},
+			BirthCounts:   []int{3},
+		},
+	},
+}
+
+var briansBrainRuleSet = RuleSet{
+	Name: "Brian's Brain",
+	Species: map[component.LifeType]SpeciesRule{
+		component.Life: {
+			Type:        component.Life,
+			BirthCounts: []int{2},
+			Reactions: []Reaction{
+				{TriggerType: component.Life, MinCount: 0, BecomesType: component.Dying},
+			},
+		},
+		component.Dying: {
+			Type: component.Dying,
+			Reactions: []Reaction{
+				{TriggerType: component.Dying, MinCount: 0, BecomesType: component.Dead},
+			},
+		},
+	},
+}
+
+var zombieEpidemicRuleSet = RuleSet{
+	Name: "Zombie Epidemic",
+	Species: map[component.LifeType]SpeciesRule{
+		component.Life: {
+			Type:          component.Life,
+			SurviveCounts: []int{2, 3},
+			BirthCounts:   []int{3},
+			Reactions: []Reaction{
+				{TriggerType: component.Zombie, MinCount: 2, BecomesType: component.Zombie},
+			},
+		},
+		component.Zombie: {
+			Type:          component.Zombie,
+			SurviveCounts: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	},
+}
+
+// Presets lists the built-in RuleSets in the order the [P] hotkey
+// cycles through them.
+var Presets = []RuleSet{conwayRuleSet, briansBrainRuleSet, zombieEpidemicRuleSet}
+
+// ZombieStarveTicks is how many consecutive ticks a Zombie can go
+// without a Life neighbor before it starves and is removed.
+const ZombieStarveTicks = 30
+
+// PresetNames lists every selectable simulation mode in [P]-hotkey
+// order: the neighbor-count CA presets first, then the health/energy
+// driven Ecosystem model.
+var PresetNames = append(presetNames(), "Ecosystem")
+
+func presetNames() []string {
+	names := make([]string, len(Presets))
+	for i, p := range Presets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// NewSimulatorForPreset returns the Simulator for preset index i. rng
+// seeds whatever stochastic behavior that preset uses — currently only
+// Food regrowth in the Ecosystem model — so a seeded replay reproduces
+// it exactly. i can arrive from a hand-edited save file rather than the
+// in-game cycling that keeps it in range, so an out-of-bounds index
+// (including negative) falls back to the Ecosystem model instead of
+// panicking on Presets[i].
+func NewSimulatorForPreset(i int, rng *rand.Rand) Simulator {
+	if i >= 0 && i < len(Presets) {
+		return NewRuleSystem(Presets[i])
+	}
+	return NewEcosystemSystem(rng)
+}
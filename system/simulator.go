@@ -0,0 +1,13 @@
+package system
+
+import "github.com/quartermeat/cell_auto_port/component"
+
+// Simulator advances a World by one tick under whatever rules it
+// implements. RuleSystem (neighbor-count CA rules) and EcosystemSystem
+// (per-species health/energy/age behaviors) both satisfy it, so Game
+// can swap between presets without caring which kind backs the current
+// one.
+type Simulator interface {
+	Update(w *component.World)
+	Name() string
+}
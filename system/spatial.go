@@ -0,0 +1,66 @@
+package system
+
+import "github.com/quartermeat/cell_auto_port/component"
+
+// neighborOffsets is the Moore (8-connected) neighborhood used by every
+// per-tick system that needs to look at the cells surrounding a Position.
+var neighborOffsets = [8][2]int{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+// buildSpatialHash snapshots every occupied Position as of this call, so
+// a system can look neighbors up in O(1) without its own decisions about
+// who moves or dies this tick changing the answer mid-pass.
+func buildSpatialHash(w *component.World) map[[2]int]component.Entity {
+	hash := make(map[[2]int]component.Entity, len(w.Positions))
+	for e, p := range w.Positions {
+		hash[[2]int{p.X, p.Y}] = e
+	}
+	return hash
+}
+
+// emptyNeighbor returns the first unoccupied cell adjacent to pos in
+// hash, if any.
+func emptyNeighbor(hash map[[2]int]component.Entity, pos component.Position) (component.Position, bool) {
+	for _, off := range neighborOffsets {
+		npos := [2]int{pos.X + off[0], pos.Y + off[1]}
+		if _, occupied := hash[npos]; !occupied {
+			return component.Position{X: npos[0], Y: npos[1]}, true
+		}
+	}
+	return component.Position{}, false
+}
+
+// SpeciesSnapshot freezes every entity's LifeType as of the start of a
+// tick. Neighbor lookups must read from this instead of the live
+// w.Species map, which RuleSystem/EcosystemSystem mutate in place
+// (removals, reclassifications) partway through the same Update pass —
+// reading live would make a neighbor's observed type depend on Go's
+// unspecified map iteration order over chunk.Entities.
+type SpeciesSnapshot map[component.Entity]component.LifeType
+
+// snapshotSpecies captures w.Species as it stands right now, before the
+// caller's Update starts mutating it.
+func snapshotSpecies(w *component.World) SpeciesSnapshot {
+	snap := make(SpeciesSnapshot, len(w.Species))
+	for e, sp := range w.Species {
+		snap[e] = sp.Type
+	}
+	return snap
+}
+
+// neighborOfType returns the first neighbor of pos in hash whose frozen
+// species matches t, if any.
+func neighborOfType(species SpeciesSnapshot, hash map[[2]int]component.Entity, pos component.Position, t component.LifeType) (component.Entity, bool) {
+	for _, off := range neighborOffsets {
+		npos := [2]int{pos.X + off[0], pos.Y + off[1]}
+		if e, ok := hash[npos]; ok {
+			if st, ok := species[e]; ok && st == t {
+				return e, true
+			}
+		}
+	}
+	return 0, false
+}